@@ -0,0 +1,161 @@
+package main
+
+import "math/rand"
+
+// HeuristicKind identifies which heuristic formula DeltaHeuristic should
+// use to compute an incremental update.
+type HeuristicKind int
+
+const (
+	// HeuristicMisplaced corresponds to DiffN (count of misplaced tiles).
+	HeuristicMisplaced HeuristicKind = iota
+	// HeuristicManhattan corresponds to ManhattanDistance.
+	HeuristicManhattan
+	// HeuristicLinearConflict corresponds to LinearConflict.
+	HeuristicLinearConflict
+)
+
+// heuristicFor returns the full-board Heuristic matching kind.
+func heuristicFor(kind HeuristicKind) Heuristic {
+	switch kind {
+	case HeuristicManhattan:
+		return (*Board).ManhattanDistance
+	case HeuristicLinearConflict:
+		return (*Board).LinearConflict
+	default:
+		return (*Board).DiffN
+	}
+}
+
+// rowConflictCount counts the tiles in board's row `row` that share
+// tile's goal row and are in reversed relative order with it, i.e. the
+// number of linear-conflict pairs tile currently takes part in along
+// that row.
+func rowConflictCount(board *Board, idx map[int][2]int, tile, row, col int) int {
+	goal := idx[tile]
+	if goal[0] != row {
+		return 0
+	}
+
+	count := 0
+	for k := 0; k < board.size; k++ {
+		v := board.state[row][k]
+		if v == 0 || v == tile {
+			continue
+		}
+
+		tv := idx[v]
+		if tv[0] != row {
+			continue
+		}
+
+		if (col < k && goal[1] > tv[1]) || (col > k && goal[1] < tv[1]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// colConflictCount is rowConflictCount's column-wise counterpart.
+func colConflictCount(board *Board, idx map[int][2]int, tile, row, col int) int {
+	goal := idx[tile]
+	if goal[1] != col {
+		return 0
+	}
+
+	count := 0
+	for k := 0; k < board.size; k++ {
+		v := board.state[k][col]
+		if v == 0 || v == tile {
+			continue
+		}
+
+		tv := idx[v]
+		if tv[1] != col {
+			continue
+		}
+
+		if (row < k && goal[0] > tv[0]) || (row > k && goal[0] < tv[0]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// DeltaHeuristic returns the signed change in the heuristic identified by
+// h that results from moving movedTile from (fromI, fromJ) to (toI, toJ)
+// (i.e. swapping it with the 0 tile), such that the new total heuristic
+// equals the old total plus this delta. Since a move only changes the
+// position of the 0 tile and movedTile, this is an O(1) (or O(size) for
+// HeuristicLinearConflict) alternative to recomputing the heuristic over
+// the whole board.
+//
+// b must be the board *before* the move (the receiver passed to
+// randomNeighborMove) - the other tiles it reports positions for are read
+// from b and are assumed unchanged by the move.
+func (b *Board) DeltaHeuristic(target *Board, movedTile, fromI, fromJ, toI, toJ int, h HeuristicKind) int {
+	idx := target.targetIndexMap()
+	t := idx[movedTile]
+
+	switch h {
+	case HeuristicManhattan:
+		oldD := abs(fromI-t[0]) + abs(fromJ-t[1])
+		newD := abs(toI-t[0]) + abs(toJ-t[1])
+		return newD - oldD
+	case HeuristicLinearConflict:
+		oldD := abs(fromI-t[0]) + abs(fromJ-t[1])
+		newD := abs(toI-t[0]) + abs(toJ-t[1])
+
+		before := rowConflictCount(b, idx, movedTile, fromI, fromJ) + colConflictCount(b, idx, movedTile, fromI, fromJ)
+		after := rowConflictCount(b, idx, movedTile, toI, toJ) + colConflictCount(b, idx, movedTile, toI, toJ)
+
+		return (newD - oldD) + 2*(after-before)
+	default: // HeuristicMisplaced
+		oldMisplaced, newMisplaced := 0, 0
+		if fromI != t[0] || fromJ != t[1] {
+			oldMisplaced = 1
+		}
+		if toI != t[0] || toJ != t[1] {
+			newMisplaced = 1
+		}
+		return newMisplaced - oldMisplaced
+	}
+}
+
+// randomNeighborMove picks a random tile adjacent to the 0 tile, swaps it
+// in, and reports the resulting board along with the moved tile and its
+// old/new coordinates so callers can feed them to DeltaHeuristic.
+func (b *Board) randomNeighborMove() (next *Board, tile, fromI, fromJ, toI, toJ int, ok bool) {
+	zi, zj, found := b.tileIndx(0)
+	if !found {
+		return nil, 0, 0, 0, 0, 0, false
+	}
+
+	type pos struct{ i, j int }
+	var candidates []pos
+	if zi-1 >= 0 {
+		candidates = append(candidates, pos{zi - 1, zj})
+	}
+	if zi+1 < b.size {
+		candidates = append(candidates, pos{zi + 1, zj})
+	}
+	if zj-1 >= 0 {
+		candidates = append(candidates, pos{zi, zj - 1})
+	}
+	if zj+1 < b.size {
+		candidates = append(candidates, pos{zi, zj + 1})
+	}
+	if len(candidates) == 0 {
+		return nil, 0, 0, 0, 0, 0, false
+	}
+
+	p := candidates[rand.Intn(len(candidates))]
+	tile = b.state[p.i][p.j]
+
+	next = b.Copy()
+	next.state[zi][zj], next.state[p.i][p.j] = next.state[p.i][p.j], next.state[zi][zj]
+
+	return next, tile, p.i, p.j, zi, zj, true
+}
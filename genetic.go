@@ -0,0 +1,275 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// GAParams are the tuning parameters for MinDiffGA.
+type GAParams struct {
+	Objective      *Board
+	PopulationSize int
+	Elitism        int
+	TournamentK    int
+	CrossoverRate  float64
+	MutationRate   float64
+	Generations    int
+
+	// Heuristic scores individuals against Objective; defaults to
+	// Board.DiffN (misplaced tiles) when nil.
+	Heuristic Heuristic
+
+	// RepairToReachable swaps two tiles in any new child (after
+	// crossover and mutation) that isn't reachable from Objective by
+	// legal slide moves, since PMX crossover and swap mutation on a
+	// flattened permutation do not preserve reachability. The initial
+	// random population is not repaired.
+	RepairToReachable bool
+}
+
+// flatten returns b's tiles in row-major order.
+func (b *Board) flatten() []int {
+	flat := make([]int, 0, b.size*b.size)
+	for i := 0; i < b.size; i++ {
+		flat = append(flat, b.state[i]...)
+	}
+
+	return flat
+}
+
+// unflatten builds a Board of size n from tiles in row-major order.
+func unflatten(flat []int, n int) *Board {
+	state := make([][]int, n)
+	for i := 0; i < n; i++ {
+		state[i] = append([]int(nil), flat[i*n:(i+1)*n]...)
+	}
+
+	return &Board{size: n, state: state}
+}
+
+// Reachable reports whether b is reachable from target by legal slide
+// moves, using the standard n-puzzle parity test: the permutation of b
+// relative to target's tile ordering must have even inversion parity for
+// odd-sized boards, or inversion parity matching the blank's row-distance
+// parity for even-sized boards.
+func (b *Board) Reachable(target *Board) bool {
+	if b.size != target.size {
+		panic("boards must be of the same size")
+	}
+
+	idx := target.targetIndexMap()
+
+	seq := make([]int, 0, b.size*b.size-1)
+	for _, v := range b.flatten() {
+		if v == 0 {
+			continue
+		}
+
+		t := idx[v]
+		seq = append(seq, t[0]*b.size+t[1])
+	}
+
+	inversions := 0
+	for i := 0; i < len(seq); i++ {
+		for j := i + 1; j < len(seq); j++ {
+			if seq[i] > seq[j] {
+				inversions++
+			}
+		}
+	}
+
+	if b.size%2 == 1 {
+		return inversions%2 == 0
+	}
+
+	bZi, _, _ := b.tileIndx(0)
+	tZi, _, _ := target.tileIndx(0)
+
+	return (inversions+abs(bZi-tZi))%2 == 0
+}
+
+// mutateSwap swaps two random tiles in place.
+func (b *Board) mutateSwap() {
+	i1, j1 := rand.Intn(b.size), rand.Intn(b.size)
+	i2, j2 := rand.Intn(b.size), rand.Intn(b.size)
+	b.state[i1][j1], b.state[i2][j2] = b.state[i2][j2], b.state[i1][j1]
+}
+
+// repairParity swaps the first two non-zero tiles found, flipping the
+// board's permutation parity by exactly one transposition.
+func (b *Board) repairParity() {
+	n := b.size
+	var idxs []int
+	for i, v := range b.flatten() {
+		if v == 0 {
+			continue
+		}
+
+		idxs = append(idxs, i)
+		if len(idxs) == 2 {
+			break
+		}
+	}
+
+	if len(idxs) < 2 {
+		return
+	}
+
+	i, j := idxs[0], idxs[1]
+	b.state[i/n][i%n], b.state[j/n][j%n] = b.state[j/n][j%n], b.state[i/n][i%n]
+}
+
+// pmxCrossover produces a child permutation from a and b using
+// partially-mapped crossover: the segment between two random cut points
+// is copied from a, and every other position is filled from b, remapped
+// through a's segment so the result stays a valid permutation.
+func pmxCrossover(a, b *Board) *Board {
+	n := a.size
+	total := n * n
+	pa := a.flatten()
+	pb := b.flatten()
+
+	c1, c2 := rand.Intn(total), rand.Intn(total)
+	if c1 > c2 {
+		c1, c2 = c2, c1
+	}
+
+	const empty = -1
+	child := make([]int, total)
+	for i := range child {
+		child[i] = empty
+	}
+
+	inSegment := make(map[int]bool, c2-c1)
+	for i := c1; i < c2; i++ {
+		child[i] = pa[i]
+		inSegment[pa[i]] = true
+	}
+
+	for i := c1; i < c2; i++ {
+		val := pb[i]
+		if inSegment[val] {
+			continue
+		}
+
+		// val's natural slot is taken by pa[i]; follow the mapping
+		// chain through pb until we land outside the copied segment.
+		j := i
+		for {
+			mapped := pa[j]
+			j = indexOf(pb, mapped)
+			if j < c1 || j >= c2 {
+				break
+			}
+		}
+		child[j] = val
+	}
+
+	for i := 0; i < total; i++ {
+		if child[i] == empty {
+			child[i] = pb[i]
+		}
+	}
+
+	return unflatten(child, n)
+}
+
+func indexOf(s []int, v int) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// tournamentSelect samples k individuals uniformly and returns the one
+// with the lowest score. k <= 0 is treated as k = 1.
+func tournamentSelect(pop []*Board, scores []int, k int) *Board {
+	if k < 1 {
+		k = 1
+	}
+
+	best := -1
+	for i := 0; i < k; i++ {
+		idx := rand.Intn(len(pop))
+		if best == -1 || scores[idx] < scores[best] {
+			best = idx
+		}
+	}
+
+	return pop[best]
+}
+
+// rankByScore returns pop's indices sorted ascending by scores.
+func rankByScore(scores []int) []int {
+	idx := make([]int, len(scores))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.Slice(idx, func(i, j int) bool { return scores[idx[i]] < scores[idx[j]] })
+
+	return idx
+}
+
+// MinDiffGA evolves a population of board permutations toward p.Objective
+// using a genetic algorithm (tournament selection, PMX crossover, swap
+// mutation, elitism) and reports the best heuristic value found across
+// all generations on ch.
+func (b *Board) MinDiffGA(p *GAParams, ch chan int) {
+	h := p.Heuristic
+	if h == nil {
+		h = (*Board).DiffN
+	}
+
+	pop := make([]*Board, p.PopulationSize)
+	for i := range pop {
+		pop[i] = NewBoardRand(b.size)
+	}
+
+	best := math.MaxInt32
+	for g := 0; g < p.Generations; g++ {
+		scores := make([]int, len(pop))
+		for i, ind := range pop {
+			scores[i] = h(ind, p.Objective)
+			if scores[i] < best {
+				best = scores[i]
+			}
+		}
+
+		ranked := rankByScore(scores)
+		next := make([]*Board, 0, len(pop))
+		for i := 0; i < p.Elitism && i < len(ranked); i++ {
+			next = append(next, pop[ranked[i]])
+		}
+
+		for len(next) < len(pop) {
+			parentA := tournamentSelect(pop, scores, p.TournamentK)
+			parentB := tournamentSelect(pop, scores, p.TournamentK)
+
+			var child *Board
+			if rand.Float64() < p.CrossoverRate {
+				child = pmxCrossover(parentA, parentB)
+			} else {
+				child = parentA.Copy()
+			}
+
+			if rand.Float64() < p.MutationRate {
+				child.mutateSwap()
+			}
+
+			if p.RepairToReachable && !child.Reachable(p.Objective) {
+				child.repairParity()
+			}
+
+			next = append(next, child)
+		}
+
+		pop = next
+	}
+
+	ch <- best
+}
@@ -0,0 +1,139 @@
+package main
+
+// Heuristic scores how far b is from target; lower values mean closer to
+// target. Implementations are swapped into SimulatedAnnealParams and
+// HillClimbParams to change what MinDiffSA/MinDiffHC optimize.
+type Heuristic func(b, target *Board) int
+
+// targetIndexMap returns b's tile->(row,col) positions, building and
+// caching it on first use. Intended to be called on a fixed objective
+// board that is reused across many heuristic evaluations, including
+// concurrently from multiple goroutines (e.g. RunHillClimb/RunSimAnneal
+// sharing one Objective) - targetIndexOnce makes the lazy build itself
+// safe, though the resulting map must still only be read afterward.
+func (b *Board) targetIndexMap() map[int][2]int {
+	b.targetIndexOnce.Do(func() {
+		idx := make(map[int][2]int, b.size*b.size)
+		for i := 0; i < b.size; i++ {
+			for j := 0; j < b.size; j++ {
+				idx[b.state[i][j]] = [2]int{i, j}
+			}
+		}
+		b.targetIndex = idx
+	})
+
+	return b.targetIndex
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+// ManhattanDistance returns the sum of |dx|+|dy| between every non-zero
+// tile's current position on b and its position on target. This is an
+// admissible heuristic for the n-puzzle and converges much faster than
+// DiffN under hill-climbing and simulated annealing.
+func (b *Board) ManhattanDistance(target *Board) int {
+	if b.size != target.size {
+		panic("boards must be of the same size")
+	}
+
+	idx := target.targetIndexMap()
+
+	d := 0
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			v := b.state[i][j]
+			if v == 0 {
+				continue
+			}
+
+			t := idx[v]
+			d += abs(i-t[0]) + abs(j-t[1])
+		}
+	}
+
+	return d
+}
+
+// LinearConflict returns ManhattanDistance plus 2 for every pair of tiles
+// that share their goal row or column, are both in that row/column on b,
+// and are in reversed relative order - each such pair requires at least
+// two extra moves beyond the Manhattan estimate for one tile to step
+// around the other.
+func (b *Board) LinearConflict(target *Board) int {
+	if b.size != target.size {
+		panic("boards must be of the same size")
+	}
+
+	c := b.ManhattanDistance(target)
+	idx := target.targetIndexMap()
+
+	// Row conflicts.
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			v1 := b.state[i][j]
+			if v1 == 0 {
+				continue
+			}
+
+			t1 := idx[v1]
+			if t1[0] != i {
+				continue
+			}
+
+			for k := j + 1; k < b.size; k++ {
+				v2 := b.state[i][k]
+				if v2 == 0 {
+					continue
+				}
+
+				t2 := idx[v2]
+				if t2[0] != i {
+					continue
+				}
+
+				if t1[1] > t2[1] {
+					c += 2
+				}
+			}
+		}
+	}
+
+	// Column conflicts.
+	for j := 0; j < b.size; j++ {
+		for i := 0; i < b.size; i++ {
+			v1 := b.state[i][j]
+			if v1 == 0 {
+				continue
+			}
+
+			t1 := idx[v1]
+			if t1[1] != j {
+				continue
+			}
+
+			for k := i + 1; k < b.size; k++ {
+				v2 := b.state[k][j]
+				if v2 == 0 {
+					continue
+				}
+
+				t2 := idx[v2]
+				if t2[1] != j {
+					continue
+				}
+
+				if t1[0] > t2[0] {
+					c += 2
+				}
+			}
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNoSolution is returned by SolveIDAStar when the search space is
+// exhausted or the search is aborted via ctx/maxTime before a solution is
+// found.
+var ErrNoSolution = errors.New("no solution found")
+
+// ApplyMove returns a copy of b with the 0 tile swapped with tile, which
+// must be orthogonally adjacent to 0.
+func (b *Board) ApplyMove(tile int) *Board {
+	zi, zj, ok := b.tileIndx(0)
+	if !ok {
+		panic("board has no 0 tile")
+	}
+
+	ti, tj, ok := b.tileIndx(tile)
+	if !ok {
+		panic("tile not found on board")
+	}
+
+	if abs(zi-ti)+abs(zj-tj) != 1 {
+		panic("tile is not adjacent to the 0 tile")
+	}
+
+	c := b.Copy()
+	c.state[zi][zj], c.state[ti][tj] = c.state[ti][tj], c.state[zi][zj]
+
+	return c
+}
+
+// SolutionPath replays moves starting from b and returns the boards
+// visited along the way, with b itself as the first entry.
+func (b *Board) SolutionPath(moves []int) []*Board {
+	path := make([]*Board, 0, len(moves)+1)
+	cur := b
+	path = append(path, cur)
+
+	for _, m := range moves {
+		cur = cur.ApplyMove(m)
+		path = append(path, cur)
+	}
+
+	return path
+}
+
+// movableTiles returns the tiles orthogonally adjacent to the 0 tile,
+// excluding skip (used to avoid immediately undoing the previous move).
+func (b *Board) movableTiles(skip int) []int {
+	zi, zj, ok := b.tileIndx(0)
+	if !ok {
+		return nil
+	}
+
+	var tiles []int
+	consider := func(i, j int) {
+		if i < 0 || i >= b.size || j < 0 || j >= b.size {
+			return
+		}
+
+		t := b.state[i][j]
+		if t == skip {
+			return
+		}
+
+		tiles = append(tiles, t)
+	}
+
+	consider(zi-1, zj)
+	consider(zi+1, zj)
+	consider(zi, zj-1)
+	consider(zi, zj+1)
+
+	return tiles
+}
+
+// SolveIDAStar returns the optimal sequence of tiles swapped into the 0
+// tile to go from b to target, using iterative deepening on f = g + h. The
+// search aborts with ErrNoSolution once ctx is done or, if maxTime is
+// non-zero, once maxTime has elapsed.
+func (b *Board) SolveIDAStar(ctx context.Context, target *Board, h Heuristic, maxTime time.Duration) ([]int, error) {
+	start := time.Now()
+	bound := h(b, target)
+	path := make([]int, 0)
+
+	aborted := func() bool {
+		if maxTime > 0 && time.Since(start) > maxTime {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return true
+		default:
+			return false
+		}
+	}
+
+	var search func(cur *Board, g, prevTile int) (int, bool)
+	search = func(cur *Board, g, prevTile int) (int, bool) {
+		if aborted() {
+			return 0, false
+		}
+
+		f := g + h(cur, target)
+		if f > bound {
+			return f, false
+		}
+
+		if cur.DiffN(target) == 0 {
+			return f, true
+		}
+
+		minNext := math.MaxInt32
+		for _, tile := range cur.movableTiles(prevTile) {
+			next := cur.ApplyMove(tile)
+			path = append(path, tile)
+
+			nf, found := search(next, g+1, tile)
+			if found {
+				return nf, true
+			}
+
+			if nf < minNext {
+				minNext = nf
+			}
+
+			path = path[:len(path)-1]
+		}
+
+		return minNext, false
+	}
+
+	for {
+		nextBound, found := search(b, 0, -1)
+		if found {
+			moves := make([]int, len(path))
+			copy(moves, path)
+			return moves, nil
+		}
+
+		if aborted() || nextBound == math.MaxInt32 {
+			return nil, ErrNoSolution
+		}
+
+		bound = nextBound
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -13,6 +14,12 @@ import (
 type Board struct {
 	size  int
 	state [][]int
+
+	// targetIndex caches this board's tile->(row,col) positions, built
+	// lazily (and safely for concurrent callers, via targetIndexOnce) the
+	// first time this board is used as a heuristic target.
+	targetIndex     map[int][2]int
+	targetIndexOnce sync.Once
 }
 
 // NewBoard initializes a new Board of size n*n with pieces set
@@ -153,28 +160,43 @@ func (b *Board) FindNeighborRand(x int) *Board {
 	return ns[i]
 }
 
+// HillClimbParams are the tuning parameters for the hill-climbing
+// experiment.
+type HillClimbParams struct {
+	Objective *Board
+
+	// Heuristic scores candidate boards against Objective; defaults to
+	// Board.DiffN (misplaced tiles) when nil.
+	Heuristic Heuristic
+}
+
 // MinDiffHC uses a hill-climbing algorithm to find the minimum*
 // number of moves to go from b to target.
 //
 // *Not guaranteed to be the global minimum, most often a local
-func (b *Board) MinDiffHC(target *Board, ch chan int) {
+func (b *Board) MinDiffHC(p *HillClimbParams, ch chan int) {
+	h := p.Heuristic
+	if h == nil {
+		h = (*Board).DiffN
+	}
+
 	currentBoard := b
 
 	for {
 		var nextBoard *Board
-		h := math.MaxInt32 // minimize h
+		best := math.MaxInt32 // minimize h
 
 		candidates := currentBoard.FindNeighbors(0)
 		for _, c := range candidates {
-			diff := c.DiffN(target)
-			if diff < h {
+			diff := h(c, p.Objective)
+			if diff < best {
 				nextBoard = c
-				h = diff
+				best = diff
 			}
 		}
 
-		currentH := currentBoard.DiffN(target)
-		if h >= currentH {
+		currentH := h(currentBoard, p.Objective)
+		if best >= currentH {
 			ch <- currentH
 			return
 		}
@@ -191,6 +213,36 @@ type SimulatedAnnealParams struct {
 	Alpha          float64
 	Iterations     int
 	MaxTime        time.Duration
+
+	// Heuristic scores candidate boards against Objective; when nil it
+	// defaults to the heuristic HeuristicKind identifies (Board.DiffN for
+	// the zero value, HeuristicMisplaced). HeuristicKind must identify
+	// the same formula so MinDiffSA can update the score per move via
+	// DeltaHeuristic (O(1), or O(size) for HeuristicLinearConflict)
+	// instead of rescoring the whole board.
+	Heuristic     Heuristic
+	HeuristicKind HeuristicKind
+
+	// Schedule computes the temperature for each cooling step; defaults
+	// to GeometricSchedule{Alpha} when nil, matching the original
+	// t *= Alpha behavior.
+	Schedule Schedule
+	// Steps is the planned number of cooling steps, passed as kMax to
+	// Schedule.Temperature. Ignored by GeometricSchedule.
+	Steps int
+
+	// Restart, if set, reseeds currentBoard from a fresh random board
+	// when progress stalls, while the global best diff/iteration found
+	// across all restarts is still reported at the end.
+	Restart RestartPolicy
+}
+
+// SAResult is the outcome MinDiffSA reports on its channel: the best
+// heuristic value found across the whole run (including any restarts)
+// and the iteration at which it was first reached.
+type SAResult struct {
+	BestDiff      int
+	BestIteration int
 }
 
 // AcceptCandidate is a standard probability function for evaulating
@@ -214,44 +266,80 @@ func AcceptCandidate(current, candidate int, temp float64) bool {
 // number of moves to go from b to target.
 //
 // *Could be global minimum or a close approximation
-func (b *Board) MinDiffSA(p *SimulatedAnnealParams, ch chan int) {
+func (b *Board) MinDiffSA(p *SimulatedAnnealParams, ch chan SAResult) {
+	h := p.Heuristic
+	if h == nil {
+		h = heuristicFor(p.HeuristicKind)
+	}
+
+	schedule := p.Schedule
+	if schedule == nil {
+		schedule = GeometricSchedule{Alpha: p.Alpha}
+	}
+
 	currentBoard := b
-	currentDiff := b.DiffN(p.Objective)
-	t := 1.00
+	currentDiff := h(currentBoard, p.Objective)
 	tMin := p.TemperatureMin
-	alpha := p.Alpha
-	minDiff := currentDiff
+
+	bestDiff := currentDiff
+	bestIteration := 0
+	sinceImprovement := 0
+	restarts := 0
+	iteration := 0
 
 	start := time.Now()
-	for t > tMin {
+	for k := 0; ; k++ {
+		t := schedule.Temperature(k, p.Steps)
+		if t <= tMin {
+			break
+		}
+
 		for i := 0; i < p.Iterations; i++ {
-			candidateBoard := currentBoard.FindNeighborRand(0)
-			candidateDiff := candidateBoard.DiffN(p.Objective)
-			if candidateDiff < minDiff {
-				minDiff = candidateDiff
+			iteration++
+
+			candidateBoard, tile, fi, fj, ti, tj, ok := currentBoard.randomNeighborMove()
+			if !ok {
+				continue
+			}
+
+			delta := currentBoard.DeltaHeuristic(p.Objective, tile, fi, fj, ti, tj, p.HeuristicKind)
+			candidateDiff := currentDiff + delta
+			if candidateDiff < bestDiff {
+				bestDiff = candidateDiff
+				bestIteration = iteration
+				sinceImprovement = 0
+			} else {
+				sinceImprovement++
 			}
 
-			if AcceptCandidate(currentDiff, candidateDiff, t) {
+			accepted := AcceptCandidate(currentDiff, candidateDiff, t)
+			if accepted {
 				currentBoard = candidateBoard
 				currentDiff = candidateDiff
 			}
+
+			if obs, ok := schedule.(scheduleObserver); ok {
+				obs.Observe(accepted)
+			}
+
+			if p.Restart != nil && p.Restart.ShouldRestart(iteration, sinceImprovement, restarts) {
+				currentBoard = NewBoardRand(b.size)
+				currentDiff = h(currentBoard, p.Objective)
+				if currentDiff < bestDiff {
+					bestDiff = currentDiff
+					bestIteration = iteration
+				}
+				sinceImprovement = 0
+				restarts++
+			}
 		}
-		t *= alpha
 
 		if time.Since(start) > p.MaxTime {
-			if minDiff < currentDiff {
-				ch <- minDiff
-			}
-			ch <- currentDiff
-			return
+			break
 		}
 	}
 
-	if minDiff < currentDiff {
-		ch <- minDiff
-	}
-	ch <- currentDiff
-	return
+	ch <- SAResult{BestDiff: bestDiff, BestIteration: bestIteration}
 }
 
 // RunHillClimb runs an example experiment for hill-climbing
@@ -265,10 +353,12 @@ func RunHillClimb() {
 		diffs[i] = 0
 	}
 
+	hcParams := HillClimbParams{Objective: b1}
+
 	start := time.Now()
 	for i := 0; i < n; i++ {
 		b2 := NewBoardRand(size)
-		go b2.MinDiffHC(b1, ch)
+		go b2.MinDiffHC(&hcParams, ch)
 	}
 
 	for i := 0; i < n; i++ {
@@ -294,7 +384,7 @@ func RunSimAnneal() {
 	n := 100
 	maxTime := 10 * time.Second
 	b1 := NewBoard(size)
-	ch := make(chan int, n)
+	ch := make(chan SAResult, n)
 	diffs := make(map[int]int, size)
 	for i := 0; i < size*size; i++ {
 		diffs[i] = 0
@@ -315,8 +405,8 @@ func RunSimAnneal() {
 	}
 
 	for i := 0; i < n; i++ {
-		m := <-ch
-		diffs[m]++
+		r := <-ch
+		diffs[r.BestDiff]++
 	}
 
 	runtime := time.Since(start).Seconds()
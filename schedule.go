@@ -0,0 +1,129 @@
+package main
+
+import "math"
+
+// Schedule produces the simulated-annealing temperature at cooling step k
+// out of a planned kMax total steps.
+type Schedule interface {
+	Temperature(k, kMax int) float64
+}
+
+// GeometricSchedule multiplies the temperature by Alpha every cooling
+// step, matching MinDiffSA's original behavior.
+type GeometricSchedule struct {
+	Alpha float64
+}
+
+// Temperature returns Alpha^k.
+func (s GeometricSchedule) Temperature(k, kMax int) float64 {
+	return math.Pow(s.Alpha, float64(k))
+}
+
+// LinearSchedule decays linearly from T0 down to 0 over kMax steps.
+type LinearSchedule struct {
+	T0 float64
+}
+
+// Temperature returns (1 - k/kMax) * T0.
+func (s LinearSchedule) Temperature(k, kMax int) float64 {
+	if kMax <= 0 {
+		return 0
+	}
+
+	return (1 - float64(k)/float64(kMax)) * s.T0
+}
+
+// LogSchedule implements Boltzmann annealing: T = C / log(2+k).
+type LogSchedule struct {
+	C float64
+}
+
+// Temperature returns C / log(2+k).
+func (s LogSchedule) Temperature(k, kMax int) float64 {
+	return s.C / math.Log(2+float64(k))
+}
+
+// AdaptiveSchedule wraps a Base schedule and reheats by a factor of Reheat
+// whenever the acceptance ratio over the last Window observed candidates
+// drops below Threshold. MinDiffSA calls Observe after every accept/reject
+// decision it makes.
+type AdaptiveSchedule struct {
+	Base      Schedule
+	Window    int
+	Threshold float64
+	Reheat    float64
+
+	history []bool
+}
+
+// Observe records whether the most recent candidate was accepted.
+func (s *AdaptiveSchedule) Observe(accepted bool) {
+	s.history = append(s.history, accepted)
+	if len(s.history) > s.Window {
+		s.history = s.history[1:]
+	}
+}
+
+func (s *AdaptiveSchedule) acceptanceRatio() float64 {
+	if len(s.history) == 0 {
+		return 1
+	}
+
+	accepted := 0
+	for _, a := range s.history {
+		if a {
+			accepted++
+		}
+	}
+
+	return float64(accepted) / float64(len(s.history))
+}
+
+// Temperature returns Base's temperature, scaled by Reheat if the recent
+// acceptance ratio has fallen below Threshold.
+func (s *AdaptiveSchedule) Temperature(k, kMax int) float64 {
+	t := s.Base.Temperature(k, kMax)
+	if len(s.history) >= s.Window && s.acceptanceRatio() < s.Threshold {
+		t *= s.Reheat
+	}
+
+	return t
+}
+
+// scheduleObserver is implemented by schedules that want to see every
+// accept/reject decision, such as AdaptiveSchedule.
+type scheduleObserver interface {
+	Observe(accepted bool)
+}
+
+// RestartPolicy decides when MinDiffSA should abandon its current walk
+// and reseed currentBoard from a fresh random board.
+type RestartPolicy interface {
+	// ShouldRestart reports whether to restart now, given the total
+	// number of iterations run so far, the number of iterations since
+	// the global-best diff last improved or the last restart (whichever
+	// came later), and how many restarts have already happened.
+	ShouldRestart(iteration, sinceImprovement, restartsSoFar int) bool
+}
+
+// FixedRestarts restarts every N iterations, regardless of progress.
+type FixedRestarts struct {
+	N int
+}
+
+// ShouldRestart reports true once every N iterations, independent of
+// sinceImprovement.
+func (r FixedRestarts) ShouldRestart(iteration, sinceImprovement, restartsSoFar int) bool {
+	return r.N > 0 && iteration > 0 && iteration%r.N == 0
+}
+
+// StuckRestarts restarts once NoImproveIters iterations pass without a
+// new global-best diff.
+type StuckRestarts struct {
+	NoImproveIters int
+}
+
+// ShouldRestart reports true once sinceImprovement reaches NoImproveIters.
+func (r StuckRestarts) ShouldRestart(iteration, sinceImprovement, restartsSoFar int) bool {
+	return sinceImprovement >= r.NoImproveIters
+}